@@ -0,0 +1,53 @@
+package whttp
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/baggage"
+	"strings"
+)
+
+// BaggageFromContext returns the W3C baggage carried by ctx, or an empty
+// Baggage if none was attached.
+func BaggageFromContext(ctx context.Context) baggage.Baggage {
+	return baggage.FromContext(ctx)
+}
+
+// WithBaggageMember returns a copy of ctx with an additional baggage member
+// key=value, preserving any baggage already attached to ctx.
+func WithBaggageMember(ctx context.Context, key, value string) (context.Context, error) {
+	member, err := baggage.NewMember(key, value)
+	if err != nil {
+		return ctx, err
+	}
+
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx, err
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag), nil
+}
+
+// baggageAttrsOnto parses headerValue (a "baggage" header), attaches the
+// parsed baggage.Baggage to ctx, and sets an http.request.baggage.<key>
+// span attribute for each member whose key is in allowList (all members
+// when allowList is nil).
+func baggageAttrsOnto(ctx context.Context, headerValue string, allowList map[string]struct{}, setAttr func(key, value string)) context.Context {
+	bag, err := baggage.Parse(headerValue)
+	if err != nil {
+		return ctx
+	}
+
+	ctx = baggage.ContextWithBaggage(ctx, bag)
+
+	for _, member := range bag.Members() {
+		if allowList != nil {
+			if _, ok := allowList[strings.ToLower(member.Key())]; !ok {
+				continue
+			}
+		}
+		setAttr(member.Key(), member.Value())
+	}
+
+	return ctx
+}
@@ -0,0 +1,77 @@
+package whttp
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithBaggageMemberAddsAndPreservesMembers(t *testing.T) {
+	ctx, err := WithBaggageMember(context.Background(), "user_id", "42")
+	if err != nil {
+		t.Fatalf("WithBaggageMember returned an error: %v", err)
+	}
+
+	ctx, err = WithBaggageMember(ctx, "tenant", "acme")
+	if err != nil {
+		t.Fatalf("WithBaggageMember returned an error: %v", err)
+	}
+
+	bag := BaggageFromContext(ctx)
+	if got := bag.Member("user_id").Value(); got != "42" {
+		t.Errorf("user_id = %q, want %q", got, "42")
+	}
+	if got := bag.Member("tenant").Value(); got != "acme" {
+		t.Errorf("tenant = %q, want %q", got, "acme")
+	}
+}
+
+func TestWithBaggageMemberInvalidKeyErrors(t *testing.T) {
+	if _, err := WithBaggageMember(context.Background(), "", "42"); err == nil {
+		t.Fatalf("WithBaggageMember with an empty key did not return an error")
+	}
+}
+
+func TestBaggageAttrsOntoAllowListFiltersMembers(t *testing.T) {
+	var captured []string
+	setAttr := func(key, value string) {
+		captured = append(captured, key+"="+value)
+	}
+
+	allowList := toRedactionSet([]string{"user_id"})
+	ctx := baggageAttrsOnto(context.Background(), "user_id=42,tenant=acme", allowList, setAttr)
+
+	if len(captured) != 1 || captured[0] != "user_id=42" {
+		t.Errorf("captured = %v, want only [user_id=42]", captured)
+	}
+
+	bag := BaggageFromContext(ctx)
+	if got := bag.Member("tenant").Value(); got != "acme" {
+		t.Errorf("tenant member should still be attached to ctx even though it's not surfaced as an attribute, got %q", got)
+	}
+}
+
+func TestBaggageAttrsOntoNilAllowListCapturesEverything(t *testing.T) {
+	var captured []string
+	setAttr := func(key, value string) {
+		captured = append(captured, key+"="+value)
+	}
+
+	baggageAttrsOnto(context.Background(), "user_id=42,tenant=acme", nil, setAttr)
+
+	if len(captured) != 2 {
+		t.Errorf("captured = %v, want 2 members", captured)
+	}
+}
+
+func TestBaggageAttrsOntoInvalidHeaderIsNoop(t *testing.T) {
+	var captured []string
+	setAttr := func(key, value string) {
+		captured = append(captured, key+"="+value)
+	}
+
+	baggageAttrsOnto(context.Background(), "not a valid baggage header!!", nil, setAttr)
+
+	if len(captured) != 0 {
+		t.Errorf("captured = %v, want none for an invalid header", captured)
+	}
+}
@@ -3,6 +3,7 @@ package whttp
 import (
 	"context"
 	"fmt"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -32,16 +33,17 @@ func RecordErrorOtel(ctx context.Context, err error) {
 	if !span.IsRecording() {
 		return
 	}
-	
+
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 	}
 }
 
-func queryParamToSpan(r *http.Request, span trace.Span) {
-	otelAttributes := make([]attribute.KeyValue, 0, len(r.URL.Query()))
-	for key, values := range r.URL.Query() {
+func queryParamToSpan(r *http.Request, span trace.Span, sensitiveParams map[string]struct{}) {
+	query := redactQueryValues(sensitiveParams, r.URL.Query())
+	otelAttributes := make([]attribute.KeyValue, 0, len(query))
+	for key, values := range query {
 		for _, value := range values {
 			otelAttributes = append(otelAttributes, attribute.String("http.request.query.params."+key, value))
 		}
@@ -72,6 +74,21 @@ func formatSize(size int) string {
 	}
 }
 
+// CorrelationIDFromContext returns the correlation ID stored in ctx, if any.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	correlationID, ok := ctx.Value(CorrelationIDKey).(string)
+	return correlationID, ok && correlationID != ""
+}
+
+// GetCorrelationID returns the correlation ID stored in ctx, generating a
+// new one when ctx doesn't carry one.
+func GetCorrelationID(ctx context.Context) string {
+	if correlationID, ok := CorrelationIDFromContext(ctx); ok {
+		return correlationID
+	}
+	return uuid.New().String()
+}
+
 func GetTraceParent(ctx context.Context) string {
 	traceParent, ok := ctx.Value(TraceParent).(string)
 	if !ok || traceParent == "" {
@@ -1,4 +1,4 @@
-package httplogwrap
+package whttp
 
 import (
 	"context"
@@ -6,33 +6,32 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"net/http"
-	"strings"
 )
 
 const SpanIDKey = "span_id"
-const CorrelationIDKey = "correlation_id"
 
 type OptHttpOtel struct {
 	SetRequestIDHeader bool
 	ExtraHeaders       []string
 }
 
-type OptHttpOtelFunc func(*OptHttpOtel)
+type HttpOtelOption func(*OptHttpOtel)
 
-func WithOutSetRequestIDHeader() OptHttpOtelFunc {
+func WithOutSetRequestIDHeader() HttpOtelOption {
 	return func(opt *OptHttpOtel) {
 		opt.SetRequestIDHeader = false
 	}
 }
 
-func WithExtraHeaders(headers ...string) OptHttpOtelFunc {
+func WithExtraHeaders(headers ...string) HttpOtelOption {
 	return func(opt *OptHttpOtel) {
 		opt.ExtraHeaders = headers
 	}
 }
 
-func HttpOtel(next http.Handler, opts ...OptHttpOtelFunc) http.Handler {
+func HttpOtel(next http.Handler, opts ...HttpOtelOption) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
+		headerRedaction := defaultRedactedHeaderSet()
 		ctx, span := otel.Tracer("starting otel trace").Start(r.Context(), r.Method+" "+r.URL.Path, trace.WithAttributes(
 			attribute.String("http.url", r.URL.String()),
 			attribute.String("http.host", r.Host),
@@ -42,8 +41,8 @@ func HttpOtel(next http.Handler, opts ...OptHttpOtelFunc) http.Handler {
 			attribute.String("http.request.user_agent", r.UserAgent()),
 			attribute.String("http.request.content_type", r.Header.Get("Content-Type")),
 			attribute.Int64("http.request.content_length", r.ContentLength),
-			attribute.String("http.request.header.referer", r.Header.Get("Referer")),
-			attribute.String("http.request.header.cookie", r.Header.Get("Cookie")),
+			attribute.String("http.request.header.referer", redactHeaderValue(headerRedaction, "Referer", r.Header.Get("Referer"))),
+			attribute.String("http.request.header.cookie", redactHeaderValue(headerRedaction, "Cookie", r.Header.Get("Cookie"))),
 		))
 		defer span.End()
 
@@ -64,7 +63,7 @@ func HttpOtel(next http.Handler, opts ...OptHttpOtelFunc) http.Handler {
 		}
 
 		for _, v := range option.ExtraHeaders {
-			extraHeader := r.Header.Get(v)
+			extraHeader := redactHeaderValue(headerRedaction, v, r.Header.Get(v))
 			span.SetAttributes(attribute.String("http.request.header."+convertHeaderName(v), extraHeader))
 		}
 
@@ -79,11 +78,3 @@ func HttpOtel(next http.Handler, opts ...OptHttpOtelFunc) http.Handler {
 
 	return http.HandlerFunc(fn)
 }
-
-func convertHeaderName(headerName string) string {
-	headerName = strings.ToLower(headerName)
-
-	result := strings.ReplaceAll(headerName, "-", "_")
-
-	return result
-}
@@ -13,6 +13,7 @@ import (
 	"github.com/gorilla/schema"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.opentelemetry.io/otel/trace"
@@ -20,6 +21,7 @@ import (
 	"net/http"
 	"runtime/debug"
 	"strings"
+	"time"
 )
 
 type OptHttpOtelFunc func(*Opentelemetry)
@@ -37,6 +39,24 @@ func WithRecoverMode(logStdOutPanic bool) OptHttpOtelFunc {
 	}
 }
 
+// WithMeterProvider wires a custom metric.MeterProvider for the metrics
+// pipeline. When not set, NewOtel falls back to otel.GetMeterProvider().
+func WithMeterProvider(mp metric.MeterProvider) OptHttpOtelFunc {
+	return func(o *Opentelemetry) {
+		o.meterProvider = mp
+	}
+}
+
+// WithRoutePattern supplies the matched route template (e.g. "/users/{id}")
+// for the http.route metric attribute, so routers such as chi or
+// gorilla/mux can be plugged in. Falls back to request.URL.Path when unset
+// or when the function returns an empty string.
+func WithRoutePattern(fn func(*http.Request) string) OptHttpOtelFunc {
+	return func(o *Opentelemetry) {
+		o.routePattern = fn
+	}
+}
+
 func WithValidator(v *validator.Validate, t ut.Translator) OptHttpOtelFunc {
 	return func(opentelemetry *Opentelemetry) {
 		isDefaultValidator := false
@@ -74,6 +94,11 @@ type Opentelemetry struct {
 	recover        bool
 	logStdOutPanic bool
 	validator      *validate
+	meterProvider  metric.MeterProvider
+	routePattern   func(*http.Request) string
+	metrics        *httpMetrics
+	capturePolicy  func(*http.Request) CapturePolicy
+	ignoredPaths   []string
 }
 
 func NewOtel(opts ...OptHttpOtelFunc) *Opentelemetry {
@@ -87,6 +112,15 @@ func NewOtel(opts ...OptHttpOtelFunc) *Opentelemetry {
 		opt(o)
 	}
 
+	if o.meterProvider == nil {
+		o.meterProvider = otel.GetMeterProvider()
+	}
+	o.metrics = newHTTPMetrics(o.meterProvider)
+
+	if o.capturePolicy == nil {
+		o.capturePolicy = DefaultCapturePolicy
+	}
+
 	return o
 }
 
@@ -94,6 +128,11 @@ func (o *Opentelemetry) Trace(next http.HandlerFunc, opts ...Option) http.Handle
 
 	return func(writer http.ResponseWriter, request *http.Request) {
 
+		if isIgnoredPath(o.ignoredPaths, request.URL.Path) {
+			next.ServeHTTP(writer, request)
+			return
+		}
+
 		ctx := request.Context()
 		if o.propagators != nil {
 			ctx = o.propagators.Extract(ctx, propagation.HeaderCarrier(request.Header))
@@ -109,6 +148,9 @@ func (o *Opentelemetry) Trace(next http.HandlerFunc, opts ...Option) http.Handle
 			opt(recorder)
 		}
 
+		correlationID := GetCorrelationID(context.WithValue(ctx, CorrelationIDKey, request.Header.Get("X-Correlation-ID")))
+		ctx = context.WithValue(ctx, CorrelationIDKey, correlationID)
+
 		ctx, span := otelTracer.Start(ctx, request.Method+" "+request.URL.Path, trace.WithAttributes(
 			attribute.String("http.url", request.URL.String()),
 			semconv.ServerAddress(request.Host),
@@ -120,7 +162,40 @@ func (o *Opentelemetry) Trace(next http.HandlerFunc, opts ...Option) http.Handle
 			attribute.String("http.request.user_agent", request.UserAgent()),
 			attribute.Int64("http.request.content_length", request.ContentLength),
 		))
+		recorder.span = span
+
+		policy := o.capturePolicy(request)
+		if sampleDecision(span.SpanContext().TraceID(), policy.SampleRate) {
+			recorder.logReqBody = recorder.logReqBody && policy.LogRequestBody
+			recorder.logRespBody = recorder.logRespBody && policy.LogResponseBody
+		} else {
+			recorder.logReqBody = false
+			recorder.logRespBody = false
+		}
+		recorder.logParams = recorder.logParams && policy.LogParams
+
+		start := time.Now()
+		route := request.URL.Path
+		if o.routePattern != nil {
+			if pattern := o.routePattern(request); pattern != "" {
+				route = pattern
+			}
+		}
+		metricAttrs := []attribute.KeyValue{
+			attribute.String("http.request.method", request.Method),
+			attribute.String("http.route", route),
+			attribute.String("server.address", request.Host),
+		}
+		o.metrics.activeRequests.Add(ctx, 1, metric.WithAttributes(metricAttrs...))
+
+		var reqBodyCounter *countingReadCloser
+		if request.Body != nil {
+			reqBodyCounter = &countingReadCloser{ReadCloser: request.Body}
+			request.Body = reqBodyCounter
+		}
+
 		defer func() {
+			o.metrics.activeRequests.Add(ctx, -1, metric.WithAttributes(metricAttrs...))
 			if r := recover(); r != nil {
 				o.recoverHandler(writer, request, span, r)
 			} else {
@@ -128,10 +203,16 @@ func (o *Opentelemetry) Trace(next http.HandlerFunc, opts ...Option) http.Handle
 			}
 		}()
 
+		headerRedaction := recorder.headerRedactionSet()
 		for k, v := range request.Header {
-			headerValue := strings.Join(v, ", ")
+			headerValue := redactHeaderValue(headerRedaction, k, strings.Join(v, ", "))
 			span.SetAttributes(attribute.String("http.request.header."+convertHeaderName(k), headerValue))
 		}
+		span.SetAttributes(attribute.String("correlation_id", correlationID))
+
+		ctx = baggageAttrsOnto(ctx, request.Header.Get("baggage"), recorder.baggageAllowList, func(key, value string) {
+			span.SetAttributes(attribute.String("http.request.baggage."+key, value))
+		})
 
 		if o.propagators != nil {
 			o.propagators.Inject(ctx, propagation.HeaderCarrier(request.Header))
@@ -139,13 +220,21 @@ func (o *Opentelemetry) Trace(next http.HandlerFunc, opts ...Option) http.Handle
 		}
 
 		if recorder.logParams {
-			queryParamToSpan(request, span)
+			queryParamToSpan(request, span, recorder.sensitiveQueryParams)
 		}
 
 		ctx = context.WithValue(ctx, "log_req_body", recorder.logReqBody)
+		ctx = context.WithValue(ctx, redactedJSONFieldsKey, recorder.redactedJSONFields)
 		request = request.WithContext(ctx)
 		next.ServeHTTP(recorder, request)
 
+		respAttrs := append(metricAttrs, attribute.Int("http.response.status_code", recorder.status))
+		o.metrics.requestDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(respAttrs...))
+		o.metrics.responseBodySize.Record(ctx, int64(recorder.size), metric.WithAttributes(respAttrs...))
+		if reqBodyCounter != nil {
+			o.metrics.requestBodySize.Record(ctx, reqBodyCounter.n, metric.WithAttributes(respAttrs...))
+		}
+
 		span.SetAttributes(
 			attribute.Int("http.response.status_code", recorder.status),
 			attribute.String("http.response.size.format", formatSize(recorder.size)),
@@ -153,14 +242,21 @@ func (o *Opentelemetry) Trace(next http.HandlerFunc, opts ...Option) http.Handle
 		)
 
 		for k, v := range recorder.Header() {
-			headerValue := strings.Join(v, ", ")
+			headerValue := redactHeaderValue(headerRedaction, k, strings.Join(v, ", "))
 			span.SetAttributes(attribute.String("http.request.header."+convertHeaderName(k), headerValue))
 		}
 
-		if recorder.logRespBody {
-			span.SetAttributes(
-				attribute.String("http.response.body", recorder.buffer.String()),
-			)
+		if recorder.hijacked {
+			span.SetAttributes(attribute.Bool("http.hijacked", true))
+		} else if recorder.logRespBody && recorder.buffer != nil && !responseExceedsCaptureLimits(recorder.Header(), recorder.size) {
+			capture := captureBody("http.response.body", recorder.Header().Get("Content-Type"), recorder.buffer.Bytes(), recorder.redactedJSONFields, recorder.maxLoggedBodyBytes)
+			if recorder.truncatedBytes > 0 && capture.Attr != "" {
+				capture.Value = fmt.Sprintf("%s...(truncated %d bytes)", capture.Value, recorder.truncatedBytes)
+			}
+			setBodyCaptureAttrs(span, capture)
+			if recorder.truncatedBytes > 0 {
+				span.SetAttributes(attribute.Int("http.response.body.truncated_bytes", recorder.truncatedBytes))
+			}
 		}
 
 		span.SetName(fmt.Sprintf("%d %s %s", recorder.status, request.Method, request.URL.Path))
@@ -184,7 +280,9 @@ func (o *Opentelemetry) BindBodyRequest(w http.ResponseWriter, r *http.Request,
 	}()
 
 	if logReqBody, ok := ctx.Value(logReqBodyKey).(bool); ok && logReqBody {
-		setAttr(ctx, attribute.String("http.request.body.json", string(body)))
+		redactPaths, _ := ctx.Value(redactedJSONFieldsKey).([]string)
+		redacted, _ := redactJSONBody(body, redactPaths)
+		setAttr(ctx, attribute.String("http.request.body.json", string(redacted)))
 	}
 
 	err = json.Unmarshal(body, v)
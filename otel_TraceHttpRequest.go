@@ -1,11 +1,8 @@
-package httplogwrap
+package whttp
 
 import (
 	"bytes"
-	"context"
-	"encoding/json"
 	"fmt"
-	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"io"
@@ -13,9 +10,18 @@ import (
 	"time"
 )
 
-func TraceHttpOtel(next http.HandlerFunc, opts ...Option) http.HandlerFunc {
+// TraceHttpOtel is a lighter-weight alternative to Opentelemetry.Trace that
+// still honors the instance's capture policy and ignored paths, so
+// high-QPS endpoints and health checks configured via WithCapturePolicy/
+// WithIgnoredPaths behave the same way under either middleware.
+func (o *Opentelemetry) TraceHttpOtel(next http.HandlerFunc, opts ...Option) http.HandlerFunc {
 
 	return func(writer http.ResponseWriter, request *http.Request) {
+		if isIgnoredPath(o.ignoredPaths, request.URL.Path) {
+			next.ServeHTTP(writer, request)
+			return
+		}
+
 		start := time.Now().UTC()
 
 		recorder := &ResponseWriter{
@@ -29,18 +35,35 @@ func TraceHttpOtel(next http.HandlerFunc, opts ...Option) http.HandlerFunc {
 			opt(recorder)
 		}
 
+		ctx, requestSpan := otelTracer.Start(request.Context(), request.Method+" "+request.URL.Path)
+		defer requestSpan.End()
+		recorder.span = requestSpan
+		request = request.WithContext(ctx)
+
+		policy := o.capturePolicy(request)
+		if sampleDecision(requestSpan.SpanContext().TraceID(), policy.SampleRate) {
+			recorder.logReqBody = recorder.logReqBody && policy.LogRequestBody
+			recorder.logRespBody = recorder.logRespBody && policy.LogResponseBody
+		} else {
+			recorder.logReqBody = false
+			recorder.logRespBody = false
+		}
+		recorder.logParams = recorder.logParams && policy.LogParams
+
 		if recorder.logParams {
-			queryParamToSpan(request, request.URL.Query())
+			_, paramSpan := otelTracer.Start(ctx, "request query parameter")
+			queryParamToSpan(request, paramSpan, recorder.sensitiveQueryParams)
+			paramSpan.End()
 		}
 
 		if recorder.logReqBody && (request.Method == http.MethodPost || request.Method == http.MethodPut) {
-			_ = addRequestBodyToSpan(request)
+			_ = addRequestBodyToSpan(request, recorder.redactedJSONFields, recorder.maxLoggedBodyBytes)
 		}
 
 		next.ServeHTTP(recorder, request)
 		duration := time.Since(start)
 
-		_, span := otelTracer.Start(request.Context(), fmt.Sprintf("response body"),
+		_, span := otelTracer.Start(ctx, fmt.Sprintf("response body"),
 			trace.WithAttributes(
 				attribute.Int("http.response.status_code", recorder.status),
 				attribute.String("http.response.size.format", formatSize(recorder.size)),
@@ -49,11 +72,15 @@ func TraceHttpOtel(next http.HandlerFunc, opts ...Option) http.HandlerFunc {
 				attribute.String("http.response.header.content_type", recorder.Header().Get("Content-Type")),
 				attribute.String("http.response.header.cache_control", recorder.Header().Get("Cache-Control")),
 			))
-		if recorder.status == http.StatusOK {
-			if recorder.logRespBody {
-				span.SetAttributes(
-					attribute.String("http.response.body", recorder.buffer.String()),
-				)
+		if recorder.hijacked {
+			span.SetAttributes(attribute.Bool("http.hijacked", true))
+		} else if recorder.status == http.StatusOK {
+			if recorder.logRespBody && recorder.buffer != nil && !responseExceedsCaptureLimits(recorder.Header(), recorder.size) {
+				capture := captureBody("http.response.body", recorder.Header().Get("Content-Type"), recorder.buffer.Bytes(), recorder.redactedJSONFields, recorder.maxLoggedBodyBytes)
+				if recorder.truncatedBytes > 0 && capture.Attr != "" {
+					capture.Value = fmt.Sprintf("%s...(truncated %d bytes)", capture.Value, recorder.truncatedBytes)
+				}
+				setBodyCaptureAttrs(span, capture)
 			}
 		}
 
@@ -61,25 +88,14 @@ func TraceHttpOtel(next http.HandlerFunc, opts ...Option) http.HandlerFunc {
 	}
 }
 
-func queryParamToSpan(r *http.Request, attributes map[string][]string) {
-	_, span := otelTracer.Start(r.Context(), "request query parameter")
-	defer span.End()
-
-	otelAttributes := make([]attribute.KeyValue, 0, len(attributes))
-	for key, values := range attributes {
-		for _, value := range values {
-			otelAttributes = append(otelAttributes, attribute.String("http.request.query.params."+key, value))
-		}
-	}
-
-	span.SetAttributes(attribute.String("http.request.query.raw", r.URL.RawQuery))
-	span.SetAttributes(otelAttributes...)
-
-	return
-}
-
-func addRequestBodyToSpan(r *http.Request) error {
-	_, span := otelTracer.Start(r.Context(), "request body json")
+// addRequestBodyToSpan captures the request body onto a span via the
+// shared content-type dispatcher, so WithRedactedJSONFields and
+// WithMaxLoggedBodyBytes apply the same way they do to Opentelemetry.Trace.
+// Only a failure to read/restore the body is treated as an error; an
+// unrecognized or malformed body is not, so normal non-JSON endpoints don't
+// pollute traces with RecordError calls.
+func addRequestBodyToSpan(r *http.Request, redactedJSONFields []string, maxBytes int) error {
+	_, span := otelTracer.Start(r.Context(), "request body")
 	defer span.End()
 
 	body, err := io.ReadAll(r.Body)
@@ -93,49 +109,10 @@ func addRequestBodyToSpan(r *http.Request) error {
 		}
 	}()
 
-	var requestBody map[string]any
-	if err = json.Unmarshal(body, &requestBody); err != nil {
-		span.RecordError(err)
-		return err
-	}
-
 	r.Body = io.NopCloser(bytes.NewBuffer(body))
 
-	jsonString, err := json.Marshal(requestBody)
-	if err != nil {
-		span.RecordError(err)
-		return err
-	}
-
-	span.SetAttributes(attribute.String("http.request.body.json", string(jsonString)))
+	capture := captureBody("http.request.body", r.Header.Get("Content-Type"), body, redactedJSONFields, maxBytes)
+	setBodyCaptureAttrs(span, capture)
 
 	return nil
 }
-
-func formatSize(size int) string {
-	const (
-		KB = 1024
-		MB = 1024 * KB
-		GB = 1024 * MB
-	)
-
-	switch {
-	case size >= GB:
-		return fmt.Sprintf("%.2f GB", float64(size)/GB)
-	case size >= MB:
-		return fmt.Sprintf("%.2f MB", float64(size)/MB)
-	case size >= KB:
-		return fmt.Sprintf("%.2f KB", float64(size)/KB)
-	default:
-		return fmt.Sprintf("%d B", size)
-	}
-}
-
-func GetCorrelationID(ctx context.Context) string {
-	correlationID, ok := ctx.Value(CorrelationIDKey).(string)
-	if !ok || correlationID == "" {
-		return uuid.New().String()
-	}
-
-	return correlationID
-}
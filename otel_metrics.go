@@ -0,0 +1,129 @@
+package whttp
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"io"
+	"net/http"
+	"time"
+)
+
+var meterName = tracerName
+
+// httpDurationBuckets follows the OTel HTTP semantic conventions'
+// recommended explicit bucket boundaries for http.server.request.duration.
+var httpDurationBuckets = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10,
+}
+
+// httpBodySizeBuckets covers typical API payload sizes, in bytes.
+var httpBodySizeBuckets = []float64{
+	64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304,
+}
+
+type httpMetrics struct {
+	requestDuration  metric.Float64Histogram
+	requestBodySize  metric.Int64Histogram
+	responseBodySize metric.Int64Histogram
+	activeRequests   metric.Int64UpDownCounter
+}
+
+// newHTTPMetrics creates the RED instruments against mp. Instrument
+// creation errors are ignored, mirroring the package-level otelTracer's
+// fire-and-forget initialization; a nil-ish noop instrument from the
+// metric SDK is safe to call into.
+func newHTTPMetrics(mp metric.MeterProvider) *httpMetrics {
+	meter := mp.Meter(meterName)
+
+	requestDuration, _ := meter.Float64Histogram(
+		"http.server.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of HTTP server requests"),
+		metric.WithExplicitBucketBoundaries(httpDurationBuckets...),
+	)
+	requestBodySize, _ := meter.Int64Histogram(
+		"http.server.request.body.size",
+		metric.WithUnit("By"),
+		metric.WithDescription("Size of HTTP server request bodies"),
+		metric.WithExplicitBucketBoundaries(httpBodySizeBuckets...),
+	)
+	responseBodySize, _ := meter.Int64Histogram(
+		"http.server.response.body.size",
+		metric.WithUnit("By"),
+		metric.WithDescription("Size of HTTP server response bodies"),
+		metric.WithExplicitBucketBoundaries(httpBodySizeBuckets...),
+	)
+	activeRequests, _ := meter.Int64UpDownCounter(
+		"http.server.active_requests",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Number of in-flight HTTP server requests"),
+	)
+
+	return &httpMetrics{
+		requestDuration:  requestDuration,
+		requestBodySize:  requestBodySize,
+		responseBodySize: responseBodySize,
+		activeRequests:   activeRequests,
+	}
+}
+
+// countingReadCloser wraps a request body to track how many bytes the
+// handler actually reads, so the request-size histogram reflects real
+// consumption rather than the advertised Content-Length.
+type countingReadCloser struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Metrics records the standard HTTP server RED signals for next without
+// requiring the tracing wrapper, for callers that want metrics-only
+// instrumentation.
+func (o *Opentelemetry) Metrics(next http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		start := time.Now()
+
+		route := request.URL.Path
+		if o.routePattern != nil {
+			if pattern := o.routePattern(request); pattern != "" {
+				route = pattern
+			}
+		}
+		attrs := []attribute.KeyValue{
+			attribute.String("http.request.method", request.Method),
+			attribute.String("http.route", route),
+			attribute.String("server.address", request.Host),
+		}
+
+		ctx := request.Context()
+		o.metrics.activeRequests.Add(ctx, 1, metric.WithAttributes(attrs...))
+
+		var reqBodyCounter *countingReadCloser
+		if request.Body != nil {
+			reqBodyCounter = &countingReadCloser{ReadCloser: request.Body}
+			request.Body = reqBodyCounter
+		}
+
+		recorder := &ResponseWriter{ResponseWriter: writer}
+		defer func() {
+			o.metrics.activeRequests.Add(ctx, -1, metric.WithAttributes(attrs...))
+			if r := recover(); r != nil {
+				panic(r)
+			}
+		}()
+
+		next.ServeHTTP(recorder, request)
+
+		respAttrs := append(attrs, attribute.Int("http.response.status_code", recorder.status))
+		o.metrics.requestDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(respAttrs...))
+		o.metrics.responseBodySize.Record(ctx, int64(recorder.size), metric.WithAttributes(respAttrs...))
+		if reqBodyCounter != nil {
+			o.metrics.requestBodySize.Record(ctx, reqBodyCounter.n, metric.WithAttributes(respAttrs...))
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package whttp
+
+import (
+	"encoding/binary"
+	"go.opentelemetry.io/otel/trace"
+	"math"
+	"net/http"
+	"path"
+)
+
+// CapturePolicy controls what Trace captures for a given request: whether
+// request/response bodies and query params are logged, and what fraction
+// of requests keep body capture (SampleRate). A span is created for every
+// request regardless of SampleRate; unsampled requests only lose body capture.
+type CapturePolicy struct {
+	LogRequestBody  bool
+	LogResponseBody bool
+	LogParams       bool
+	SampleRate      float64
+}
+
+// defaultCapturePolicyMaxBytes is the declared Content-Length threshold
+// above which DefaultCapturePolicy disables body capture.
+const defaultCapturePolicyMaxBytes = 64 * 1024
+
+// DefaultCapturePolicy samples every request, disabling body capture for
+// GET/HEAD requests and for requests that look like event streams or
+// declare a body larger than defaultCapturePolicyMaxBytes. It only sees
+// the request, so callers also consult responseExceedsCaptureLimits
+// against the actual response before capturing its body.
+func DefaultCapturePolicy(r *http.Request) CapturePolicy {
+	policy := CapturePolicy{LogRequestBody: true, LogResponseBody: true, LogParams: true, SampleRate: 1}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		policy.LogRequestBody = false
+		policy.LogResponseBody = false
+	}
+
+	if r.Header.Get("Content-Type") == "text/event-stream" || r.ContentLength > defaultCapturePolicyMaxBytes {
+		policy.LogRequestBody = false
+		policy.LogResponseBody = false
+	}
+
+	return policy
+}
+
+// WithCapturePolicy configures a per-request CapturePolicy, evaluated once
+// per request right after the span is created, before any body or
+// query-param capture happens.
+func WithCapturePolicy(fn func(*http.Request) CapturePolicy) OptHttpOtelFunc {
+	return func(o *Opentelemetry) {
+		o.capturePolicy = fn
+	}
+}
+
+// WithIgnoredPaths skips span creation entirely for requests whose path
+// matches one of patterns, e.g. "/healthz", "/metrics", or a glob such as
+// "/static/*" (see path.Match for the supported glob syntax).
+func WithIgnoredPaths(patterns ...string) OptHttpOtelFunc {
+	return func(o *Opentelemetry) {
+		o.ignoredPaths = patterns
+	}
+}
+
+func isIgnoredPath(patterns []string, requestPath string) bool {
+	for _, pattern := range patterns {
+		if pattern == requestPath {
+			return true
+		}
+		if matched, err := path.Match(pattern, requestPath); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// responseExceedsCaptureLimits reports whether a response looks like an
+// event stream or is bigger than defaultCapturePolicyMaxBytes. DefaultCapturePolicy
+// only sees the request, so callers re-check this against the real response
+// header/size right before writing a body capture, closing the gap for
+// handlers that stream SSE or a large body regardless of the request shape.
+func responseExceedsCaptureLimits(header http.Header, size int) bool {
+	return header.Get("Content-Type") == "text/event-stream" || size > defaultCapturePolicyMaxBytes
+}
+
+// sampleDecision hashes the lower 8 bytes of traceID to a uniform [0, 1)
+// value and compares it against rate, mirroring trace.TraceIDRatioBased so
+// the decision stays stable for every span within the same trace.
+func sampleDecision(traceID trace.TraceID, rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	if rate >= 1 {
+		return true
+	}
+
+	lower := binary.BigEndian.Uint64(traceID[8:16])
+	return float64(lower)/float64(math.MaxUint64) < rate
+}
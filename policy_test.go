@@ -0,0 +1,73 @@
+package whttp
+
+import (
+	"encoding/binary"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"testing"
+)
+
+func TestResponseExceedsCaptureLimits(t *testing.T) {
+	eventStreamHeader := http.Header{"Content-Type": []string{"text/event-stream"}}
+	jsonHeader := http.Header{"Content-Type": []string{"application/json"}}
+
+	tests := []struct {
+		name   string
+		header http.Header
+		size   int
+		want   bool
+	}{
+		{"event stream exceeds regardless of size", eventStreamHeader, 10, true},
+		{"small json response is within limits", jsonHeader, 10, false},
+		{"json response over the max is excluded", jsonHeader, defaultCapturePolicyMaxBytes + 1, true},
+		{"json response at the max is within limits", jsonHeader, defaultCapturePolicyMaxBytes, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := responseExceedsCaptureLimits(tt.header, tt.size); got != tt.want {
+				t.Errorf("responseExceedsCaptureLimits(%v, %d) = %v, want %v", tt.header, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func traceIDWithLower(lower uint64) trace.TraceID {
+	var id trace.TraceID
+	binary.BigEndian.PutUint64(id[8:16], lower)
+	return id
+}
+
+func TestSampleDecision(t *testing.T) {
+	tests := []struct {
+		name  string
+		rate  float64
+		lower uint64
+		want  bool
+	}{
+		{"rate zero always drops", 0, 0, false},
+		{"rate one always keeps", 1, ^uint64(0), true},
+		{"negative rate drops", -1, 0, false},
+		{"lower than rate keeps", 0.5, 0, true},
+		{"at max uint64 drops under a half rate", 0.5, ^uint64(0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sampleDecision(traceIDWithLower(tt.lower), tt.rate)
+			if got != tt.want {
+				t.Errorf("sampleDecision(lower=%d, rate=%v) = %v, want %v", tt.lower, tt.rate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSampleDecisionStableAcrossCalls(t *testing.T) {
+	id := traceIDWithLower(1234567890)
+	first := sampleDecision(id, 0.5)
+	for i := 0; i < 10; i++ {
+		if got := sampleDecision(id, 0.5); got != first {
+			t.Fatalf("sampleDecision is not stable for the same trace ID: got %v, want %v", got, first)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package whttp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+)
+
+const redactedValue = "***"
+
+// defaultRedactedHeaders are redacted even when the caller never configures
+// WithRedactedHeaders, since logging these by default leaks credentials.
+var defaultRedactedHeaders = []string{
+	"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization", "X-Api-Key",
+}
+
+func defaultRedactedHeaderSet() map[string]struct{} {
+	return toRedactionSet(defaultRedactedHeaders)
+}
+
+func toRedactionSet(names []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		set[strings.ToLower(n)] = struct{}{}
+	}
+	return set
+}
+
+// redactHeaderValue returns "***" when name (case-insensitive) is in set.
+func redactHeaderValue(set map[string]struct{}, name, value string) string {
+	if _, ok := set[strings.ToLower(name)]; ok {
+		return redactedValue
+	}
+	return value
+}
+
+// redactQueryValues replaces values for sensitive query parameter keys.
+func redactQueryValues(set map[string]struct{}, values url.Values) url.Values {
+	if len(set) == 0 {
+		return values
+	}
+	redacted := make(url.Values, len(values))
+	for k, vs := range values {
+		if _, ok := set[strings.ToLower(k)]; ok {
+			redacted[k] = []string{redactedValue}
+			continue
+		}
+		redacted[k] = vs
+	}
+	return redacted
+}
+
+// redactJSONBody unmarshals body, replaces the leaf value at each dotted
+// path in paths with "***", and re-marshals it. ok is false when body isn't
+// valid JSON, so callers fall back without treating it as an error.
+func redactJSONBody(body []byte, paths []string) (out []byte, ok bool) {
+	var parsed any
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return body, false
+	}
+
+	for _, path := range paths {
+		redactJSONPath(parsed, strings.Split(path, "."))
+	}
+
+	redacted, err := json.Marshal(parsed)
+	if err != nil {
+		return body, false
+	}
+	return redacted, true
+}
+
+func redactJSONPath(node any, segments []string) {
+	if len(segments) == 0 {
+		return
+	}
+	m, ok := node.(map[string]any)
+	if !ok {
+		return
+	}
+	key := segments[0]
+	if len(segments) == 1 {
+		if _, exists := m[key]; exists {
+			m[key] = redactedValue
+		}
+		return
+	}
+	redactJSONPath(m[key], segments[1:])
+}
+
+// bodyCapture is the rendered form of a captured body ready for span
+// attributes: either a single value under Attr, or a set of per-field
+// values (form fields, multipart field/file metadata) under Fields.
+type bodyCapture struct {
+	Attr   string
+	Value  string
+	Fields map[string]string
+}
+
+// captureBody dispatches body capture by content type: JSON is redacted and
+// re-marshaled, form-encoded bodies are split into individual fields under
+// base+".form.<key>" with any field whose name (case-insensitive) appears in
+// redactedJSONFields replaced by "***", multipart bodies report only field
+// names and file names/sizes under base+".multipart.*", and any other
+// content type falls back to a bounded base64 preview under base+".raw". It
+// never signals an error for content it doesn't recognize as JSON, so
+// normal non-JSON endpoints don't pollute traces. base is typically
+// "http.request.body" or "http.response.body".
+func captureBody(base, contentType string, body []byte, redactedJSONFields []string, maxBytes int) bodyCapture {
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		redacted, ok := redactJSONBody(body, redactedJSONFields)
+		if !ok {
+			return bodyCapture{Attr: base + ".raw", Value: base64Preview(body, maxBytes)}
+		}
+		return bodyCapture{Attr: base + ".json", Value: truncateString(string(redacted), maxBytes)}
+
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return bodyCapture{Attr: base + ".raw", Value: base64Preview(body, maxBytes)}
+		}
+		redactedFields := toRedactionSet(redactedJSONFields)
+		fields := make(map[string]string, len(values))
+		for k, vs := range values {
+			value := strings.Join(vs, ", ")
+			if _, ok := redactedFields[strings.ToLower(k)]; ok {
+				value = redactedValue
+			}
+			fields[base+".form."+k] = value
+		}
+		return bodyCapture{Fields: fields}
+
+	case mediaType == "multipart/form-data":
+		boundary := params["boundary"]
+		if boundary == "" {
+			return bodyCapture{Attr: base + ".raw", Value: base64Preview(body, maxBytes)}
+		}
+		return bodyCapture{Fields: multipartFieldSummary(base, body, boundary)}
+
+	default:
+		return bodyCapture{Attr: base + ".raw", Value: base64Preview(body, maxBytes)}
+	}
+}
+
+// multipartFieldSummary reports field names and, for file parts, the
+// filename and size, but never file contents.
+func multipartFieldSummary(base string, body []byte, boundary string) map[string]string {
+	summary := make(map[string]string)
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+
+		name := part.FormName()
+		n, _ := io.Copy(io.Discard, part)
+		if fileName := part.FileName(); fileName != "" {
+			summary[base+".multipart."+name+".filename"] = fileName
+			summary[base+".multipart."+name+".size"] = fmt.Sprintf("%d", n)
+			continue
+		}
+		summary[base+".multipart."+name] = fmt.Sprintf("%d bytes", n)
+	}
+	return summary
+}
+
+func base64Preview(body []byte, maxBytes int) string {
+	if maxBytes > 0 && len(body) > maxBytes {
+		return fmt.Sprintf("%s...(truncated %d bytes)", base64.StdEncoding.EncodeToString(body[:maxBytes]), len(body)-maxBytes)
+	}
+	return base64.StdEncoding.EncodeToString(body)
+}
+
+// setBodyCaptureAttrs writes a bodyCapture to span, either as a single
+// attribute or as one attribute per field (form/multipart).
+func setBodyCaptureAttrs(span trace.Span, capture bodyCapture) {
+	if capture.Attr != "" {
+		span.SetAttributes(attribute.String(capture.Attr, capture.Value))
+		return
+	}
+	for k, v := range capture.Fields {
+		span.SetAttributes(attribute.String(k, v))
+	}
+}
+
+func truncateString(s string, maxBytes int) string {
+	if maxBytes > 0 && len(s) > maxBytes {
+		return fmt.Sprintf("%s...(truncated %d bytes)", s[:maxBytes], len(s)-maxBytes)
+	}
+	return s
+}
@@ -0,0 +1,99 @@
+package whttp
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRedactJSONBody(t *testing.T) {
+	body := []byte(`{"user":{"name":"alice","password":"hunter2"},"token":"abc"}`)
+
+	redacted, ok := redactJSONBody(body, []string{"user.password", "token"})
+	if !ok {
+		t.Fatalf("redactJSONBody reported invalid JSON for valid input")
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(redacted, &got); err != nil {
+		t.Fatalf("redacted output is not valid JSON: %v", err)
+	}
+
+	user := got["user"].(map[string]any)
+	if user["password"] != redactedValue {
+		t.Errorf("user.password = %v, want %v", user["password"], redactedValue)
+	}
+	if user["name"] != "alice" {
+		t.Errorf("user.name = %v, want unchanged value %q", user["name"], "alice")
+	}
+	if got["token"] != redactedValue {
+		t.Errorf("token = %v, want %v", got["token"], redactedValue)
+	}
+}
+
+func TestRedactJSONBodyInvalidJSON(t *testing.T) {
+	body := []byte(`not json`)
+
+	out, ok := redactJSONBody(body, []string{"token"})
+	if ok {
+		t.Fatalf("redactJSONBody reported valid JSON for malformed input")
+	}
+	if string(out) != string(body) {
+		t.Errorf("redactJSONBody returned %q for invalid JSON, want the original body unchanged", out)
+	}
+}
+
+func TestRedactJSONPathMissingKeyIsNoop(t *testing.T) {
+	node := map[string]any{"name": "alice"}
+
+	redactJSONPath(node, []string{"password"})
+
+	if _, exists := node["password"]; exists {
+		t.Errorf("redactJSONPath created a key that didn't exist: %v", node)
+	}
+	if node["name"] != "alice" {
+		t.Errorf("redactJSONPath touched an unrelated key: %v", node)
+	}
+}
+
+func TestRedactJSONPathNestedMissingParentIsNoop(t *testing.T) {
+	node := map[string]any{"name": "alice"}
+
+	redactJSONPath(node, []string{"user", "password"})
+
+	if _, exists := node["user"]; exists {
+		t.Errorf("redactJSONPath created a missing parent: %v", node)
+	}
+}
+
+func TestCaptureBodyRedactsFormFields(t *testing.T) {
+	body := []byte("username=alice&password=hunter2")
+
+	capture := captureBody("http.request.body", "application/x-www-form-urlencoded", body, []string{"password"}, 0)
+
+	if capture.Fields["http.request.body.form.password"] != redactedValue {
+		t.Errorf("form.password = %q, want %q", capture.Fields["http.request.body.form.password"], redactedValue)
+	}
+	if capture.Fields["http.request.body.form.username"] != "alice" {
+		t.Errorf("form.username = %q, want unchanged value %q", capture.Fields["http.request.body.form.username"], "alice")
+	}
+}
+
+func TestCaptureBodyFormFieldsUnredactedByDefault(t *testing.T) {
+	body := []byte("username=alice")
+
+	capture := captureBody("http.request.body", "application/x-www-form-urlencoded", body, nil, 0)
+
+	if capture.Fields["http.request.body.form.username"] != "alice" {
+		t.Errorf("form.username = %q, want unchanged value %q", capture.Fields["http.request.body.form.username"], "alice")
+	}
+}
+
+func TestRedactJSONPathEmptySegmentsIsNoop(t *testing.T) {
+	node := map[string]any{"name": "alice"}
+
+	redactJSONPath(node, nil)
+
+	if node["name"] != "alice" {
+		t.Errorf("redactJSONPath with no segments mutated the node: %v", node)
+	}
+}
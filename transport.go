@@ -0,0 +1,120 @@
+package whttp
+
+import (
+	"bytes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type roundTripper struct {
+	base        http.RoundTripper
+	propagators propagation.TextMapPropagator
+	recorder    ResponseWriter
+}
+
+// NewTransport wraps base (http.DefaultTransport when nil) so that outbound
+// requests get a client-kind span, propagator injection, and optional
+// request/response body capture symmetric to the server-side middleware.
+// opts are the same Option funcs used by Trace; only the logging/redaction
+// fields they configure are consulted.
+func NewTransport(base http.RoundTripper, opts ...Option) http.RoundTripper {
+	return newRoundTripper(base, otel.GetTextMapPropagator(), opts)
+}
+
+// Transport is the Opentelemetry-bound equivalent of NewTransport, reusing
+// the propagator configured via WithPropagator.
+func (o *Opentelemetry) Transport(base http.RoundTripper, opts ...Option) http.RoundTripper {
+	propagators := o.propagators
+	if propagators == nil {
+		propagators = otel.GetTextMapPropagator()
+	}
+	return newRoundTripper(base, propagators, opts)
+}
+
+func newRoundTripper(base http.RoundTripper, propagators propagation.TextMapPropagator, opts []Option) *roundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	recorder := ResponseWriter{}
+	for _, opt := range opts {
+		opt(&recorder)
+	}
+
+	return &roundTripper{
+		base:        base,
+		propagators: propagators,
+		recorder:    recorder,
+	}
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	req = req.Clone(ctx)
+
+	ctx, span := otelTracer.Start(ctx, "HTTP "+req.Method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("http.request.method", req.Method),
+		semconv.URLFull(req.URL.String()),
+		semconv.ServerAddress(req.URL.Hostname()),
+		attribute.Int64("http.request.content_length", req.ContentLength),
+	))
+	defer span.End()
+	req = req.WithContext(ctx)
+
+	if rt.propagators != nil {
+		rt.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
+
+	req.Header.Set("X-Correlation-ID", GetCorrelationID(ctx))
+
+	if bag := BaggageFromContext(ctx); bag.Len() > 0 {
+		req.Header.Set("baggage", bag.String())
+	}
+
+	headerRedaction := rt.recorder.headerRedactionSet()
+	for k, v := range req.Header {
+		headerValue := redactHeaderValue(headerRedaction, k, strings.Join(v, ", "))
+		span.SetAttributes(attribute.String("http.request.header."+convertHeaderName(k), headerValue))
+	}
+
+	if rt.recorder.logReqBody && req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err == nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			capture := captureBody("http.request.body", req.Header.Get("Content-Type"), body, rt.recorder.redactedJSONFields, rt.recorder.maxLoggedBodyBytes)
+			setBodyCaptureAttrs(span, capture)
+		}
+	}
+
+	resp, err := rt.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	if rt.recorder.logRespBody && resp.Body != nil {
+		body, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if readErr == nil {
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			capture := captureBody("http.response.body", resp.Header.Get("Content-Type"), body, rt.recorder.redactedJSONFields, rt.recorder.maxLoggedBodyBytes)
+			setBodyCaptureAttrs(span, capture)
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,97 @@
+package whttp
+
+import (
+	"bytes"
+	"context"
+	"go.opentelemetry.io/otel/propagation"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRoundTripperSetsCorrelationIDHeader(t *testing.T) {
+	var gotHeader string
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotHeader = req.Header.Get("X-Correlation-ID")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	rt := newRoundTripper(base, propagation.TraceContext{}, nil)
+
+	ctx := context.WithValue(context.Background(), CorrelationIDKey, "existing-id")
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil).WithContext(ctx)
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip returned an error: %v", err)
+	}
+
+	if gotHeader != "existing-id" {
+		t.Errorf("X-Correlation-ID = %q, want %q", gotHeader, "existing-id")
+	}
+}
+
+func TestRoundTripperGeneratesCorrelationIDWhenMissing(t *testing.T) {
+	var gotHeader string
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotHeader = req.Header.Get("X-Correlation-ID")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	rt := newRoundTripper(base, propagation.TraceContext{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip returned an error: %v", err)
+	}
+
+	if gotHeader == "" {
+		t.Errorf("X-Correlation-ID was not set when ctx carried no correlation ID")
+	}
+}
+
+func TestRoundTripperKeepsRedactedHeaderOnOutboundRequest(t *testing.T) {
+	var gotAuth string
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotAuth = req.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	rt := newRoundTripper(base, propagation.TraceContext{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip returned an error: %v", err)
+	}
+
+	if gotAuth != "Bearer secret" {
+		t.Errorf("RoundTrip must not mutate the outbound request's own Authorization header, got %q", gotAuth)
+	}
+}
+
+func TestRoundTripperRequestBodyCaptureKeepsBody(t *testing.T) {
+	var gotBody []byte
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotBody, _ = io.ReadAll(req.Body)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	rt := newRoundTripper(base, propagation.TraceContext{}, []Option{WithLogRequestBody(true)})
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/", bytes.NewBufferString(`{"field":"value"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip returned an error: %v", err)
+	}
+
+	if string(gotBody) != `{"field":"value"}` {
+		t.Errorf("the base transport should still receive the full request body, got %q", gotBody)
+	}
+}
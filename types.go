@@ -10,3 +10,5 @@ type Error400 struct {
 
 const TraceParent = "traceparent"
 const logReqBodyKey = "log_req_body"
+const redactedJSONFieldsKey = "redacted_json_fields"
+const CorrelationIDKey = "correlation_id"
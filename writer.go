@@ -1,8 +1,12 @@
 package whttp
 
 import (
+	"bufio"
 	"bytes"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"net"
 	"net/http"
 )
 
@@ -29,14 +33,79 @@ func WithLogParams(log bool) Option {
 	}
 }
 
+// WithRedactedHeaders replaces the set of header names (case-insensitive)
+// whose values are logged as "***" instead of their real value. Passing no
+// names disables redaction entirely.
+func WithRedactedHeaders(names ...string) Option {
+	return func(e *ResponseWriter) {
+		e.redactedHeaders = toRedactionSet(names)
+	}
+}
+
+// WithRedactedJSONFields replaces the set of dotted JSON paths (e.g.
+// "user.password") whose leaf values are redacted before a JSON body is
+// written to the span.
+func WithRedactedJSONFields(paths ...string) Option {
+	return func(e *ResponseWriter) {
+		e.redactedJSONFields = paths
+	}
+}
+
+// WithSensitiveQueryParams replaces the set of query parameter names
+// (case-insensitive) whose values are logged as "***".
+func WithSensitiveQueryParams(keys ...string) Option {
+	return func(e *ResponseWriter) {
+		e.sensitiveQueryParams = toRedactionSet(keys)
+	}
+}
+
+// WithBaggageAllowList restricts which W3C baggage keys (case-insensitive)
+// are copied into span attributes, so operators can avoid leaking sensitive
+// values that upstream services may attach to baggage. Passing no keys
+// means no baggage member is copied to span attributes.
+func WithBaggageAllowList(keys ...string) Option {
+	return func(e *ResponseWriter) {
+		e.baggageAllowList = toRedactionSet(keys)
+	}
+}
+
+// WithMaxLoggedBodyBytes caps how many bytes of a response body are captured
+// into the span attribute. Once the limit is reached further writes are
+// still forwarded to the underlying ResponseWriter but are no longer
+// buffered, and the captured attribute is suffixed with a truncation
+// marker. n <= 0 means unlimited.
+func WithMaxLoggedBodyBytes(n int) Option {
+	return func(e *ResponseWriter) {
+		e.maxLoggedBodyBytes = n
+	}
+}
+
 type ResponseWriter struct {
 	http.ResponseWriter
-	status      int
-	size        int
-	logParams   bool
-	logRespBody bool
-	logReqBody  bool
-	buffer      *bytes.Buffer
+	status             int
+	size               int
+	logParams          bool
+	logRespBody        bool
+	logReqBody         bool
+	buffer             *bytes.Buffer
+	maxLoggedBodyBytes int
+	truncatedBytes     int
+	hijacked           bool
+	span               trace.Span
+
+	redactedHeaders      map[string]struct{}
+	redactedJSONFields   []string
+	sensitiveQueryParams map[string]struct{}
+	baggageAllowList     map[string]struct{}
+}
+
+// headerRedactionSet returns the configured header redaction set, falling
+// back to the package default when the caller never customized it.
+func (rw *ResponseWriter) headerRedactionSet() map[string]struct{} {
+	if rw.redactedHeaders != nil {
+		return rw.redactedHeaders
+	}
+	return defaultRedactedHeaderSet()
 }
 
 func (rw *ResponseWriter) WriteHeader(status int) {
@@ -49,10 +118,88 @@ func (rw *ResponseWriter) Write(body []byte) (int, error) {
 		rw.status = http.StatusOK
 	}
 	size, err := rw.ResponseWriter.Write(body)
-	rw.size = size
-	if rw.logRespBody {
+	rw.size += size
+	if rw.logRespBody && !rw.hijacked {
+		rw.appendBody(body)
+	}
+	return size, err
+}
+
+// appendBody buffers body for span capture, honoring maxLoggedBodyBytes.
+func (rw *ResponseWriter) appendBody(body []byte) {
+	if rw.buffer == nil {
 		rw.buffer = new(bytes.Buffer)
+	}
+
+	if rw.maxLoggedBodyBytes <= 0 {
 		rw.buffer.Write(body)
+		return
 	}
-	return size, err
+
+	remaining := rw.maxLoggedBodyBytes - rw.buffer.Len()
+	if remaining <= 0 {
+		rw.truncatedBytes += len(body)
+		return
+	}
+
+	if len(body) > remaining {
+		rw.buffer.Write(body[:remaining])
+		rw.truncatedBytes += len(body) - remaining
+		return
+	}
+
+	rw.buffer.Write(body)
+}
+
+// Hijack implements http.Hijacker, delegating to the underlying
+// ResponseWriter when it supports hijacking. The connection is no longer
+// safe to write to or read captured body state from once hijacked, so
+// callers must stop touching rw.buffer after this returns successfully.
+func (rw *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	conn, rwBuf, err := hijacker.Hijack()
+	if err != nil {
+		return conn, rwBuf, err
+	}
+
+	rw.hijacked = true
+	if rw.span != nil {
+		rw.span.SetAttributes(attribute.Bool("http.hijacked", true))
+	}
+
+	return conn, rwBuf, nil
+}
+
+// Flush implements http.Flusher. If no status has been recorded yet it is
+// treated as an implicit 200, matching net/http's own behavior for the
+// first Write. The span is marked to indicate the response was streamed.
+func (rw *ResponseWriter) Flush() {
+	flusher, ok := rw.ResponseWriter.(http.Flusher)
+	if !ok {
+		return
+	}
+
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	if rw.span != nil {
+		rw.span.SetAttributes(attribute.Bool("http.response.streamed", true))
+	}
+
+	flusher.Flush()
+}
+
+// Push implements http.Pusher for HTTP/2 server push, delegating to the
+// underlying ResponseWriter when it supports it.
+func (rw *ResponseWriter) Push(target string, opts *http.PushOptions) error {
+	pusher, ok := rw.ResponseWriter.(http.Pusher)
+	if !ok {
+		return http.ErrNotSupported
+	}
+
+	return pusher.Push(target, opts)
 }
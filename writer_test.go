@@ -0,0 +1,58 @@
+package whttp
+
+import "testing"
+
+func TestAppendBodyUnlimited(t *testing.T) {
+	rw := &ResponseWriter{}
+
+	rw.appendBody([]byte("hello "))
+	rw.appendBody([]byte("world"))
+
+	if got := rw.buffer.String(); got != "hello world" {
+		t.Errorf("buffer = %q, want %q", got, "hello world")
+	}
+	if rw.truncatedBytes != 0 {
+		t.Errorf("truncatedBytes = %d, want 0", rw.truncatedBytes)
+	}
+}
+
+func TestAppendBodyTruncatesAtLimit(t *testing.T) {
+	rw := &ResponseWriter{maxLoggedBodyBytes: 5}
+
+	rw.appendBody([]byte("hello world"))
+
+	if got := rw.buffer.String(); got != "hello" {
+		t.Errorf("buffer = %q, want %q", got, "hello")
+	}
+	if rw.truncatedBytes != 6 {
+		t.Errorf("truncatedBytes = %d, want 6", rw.truncatedBytes)
+	}
+}
+
+func TestAppendBodyAccumulatesTruncatedBytesAcrossWrites(t *testing.T) {
+	rw := &ResponseWriter{maxLoggedBodyBytes: 5}
+
+	rw.appendBody([]byte("hello"))
+	rw.appendBody([]byte(" world"))
+	rw.appendBody([]byte("!"))
+
+	if got := rw.buffer.String(); got != "hello" {
+		t.Errorf("buffer = %q, want %q", got, "hello")
+	}
+	if rw.truncatedBytes != 7 {
+		t.Errorf("truncatedBytes = %d, want 7", rw.truncatedBytes)
+	}
+}
+
+func TestAppendBodyExactlyAtLimit(t *testing.T) {
+	rw := &ResponseWriter{maxLoggedBodyBytes: 5}
+
+	rw.appendBody([]byte("hello"))
+
+	if got := rw.buffer.String(); got != "hello" {
+		t.Errorf("buffer = %q, want %q", got, "hello")
+	}
+	if rw.truncatedBytes != 0 {
+		t.Errorf("truncatedBytes = %d, want 0", rw.truncatedBytes)
+	}
+}